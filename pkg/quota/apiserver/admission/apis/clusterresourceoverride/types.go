@@ -0,0 +1,80 @@
+package clusterresourceoverride
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ClusterResourceOverrideConfig is the configuration for the ClusterResourceOverride
+// admission controller which overwrites user-provided resource request/limit values.
+type ClusterResourceOverrideConfig struct {
+	metav1.TypeMeta
+
+	// LimitCPUToMemoryPercent if specified, sets the CPU limit of a container to the given
+	// percentage of the memory limit, replacing any CPU limit the container specified. For
+	// example, 100 means 1 core of CPU limit per 1GiB of memory limit.
+	LimitCPUToMemoryPercent int64
+	// CPURequestToLimitPercent if specified, overwrites the CPU request of a container to
+	// the given percentage of the CPU limit.
+	CPURequestToLimitPercent int64
+	// MemoryRequestToLimitPercent if specified, overwrites the memory request of a container
+	// to the given percentage of the memory limit.
+	MemoryRequestToLimitPercent int64
+	// EphemeralStorageRequestToLimitPercent if specified, overwrites the ephemeral-storage
+	// request of a container to the given percentage of the ephemeral-storage limit.
+	EphemeralStorageRequestToLimitPercent int64
+	// ExtendedResourceRequestToLimitPercent, if specified, overwrites the request of the
+	// named extended resources (e.g. "nvidia.com/gpu") to the given percentage of their
+	// limit. Resources not present in this map are left untouched.
+	ExtendedResourceRequestToLimitPercent map[string]int64
+
+	// ContainerResourceBounds, if specified, additionally enforces absolute CPU/memory
+	// limit caps and injects defaults for containers that omit limits or requests,
+	// independent of the ratio-based overrides above.
+	ContainerResourceBounds *ContainerResourceBounds
+
+	// Mode controls whether the computed overrides are actually applied to the pod.
+	// Empty defaults to ModeEnforce.
+	Mode OverrideMode
+}
+
+// OverrideMode is how the plugin acts on the values it computes for a pod.
+type OverrideMode string
+
+const (
+	// ModeEnforce rewrites the pod's requests and limits in place. This is the default.
+	ModeEnforce OverrideMode = "Enforce"
+	// ModeWarn computes the overrides but leaves the pod untouched, recording them in the
+	// quota.openshift.io/cluster-resource-override-would-set annotation and as a server-side
+	// log warning so an operator watching the apiserver logs can see what enforce mode would
+	// have done. This is an operator-facing audit signal, not a warning returned to the
+	// client that submitted the pod.
+	ModeWarn OverrideMode = "Warn"
+	// ModeAudit behaves like ModeWarn but without the log warning, for fleets that only want
+	// the annotation recorded for offline review.
+	ModeAudit OverrideMode = "Audit"
+)
+
+// ContainerResourceBounds configures absolute per-container resource caps and defaults.
+type ContainerResourceBounds struct {
+	// CPU bounds the cpu limit/request of every container; omitted means unbounded.
+	CPU *ResourceBound
+	// Memory bounds the memory limit/request of every container; omitted means unbounded.
+	Memory *ResourceBound
+}
+
+// ResourceBound describes the absolute bounds and defaults to apply to a single resource.
+type ResourceBound struct {
+	// MaxLimit, if non-zero, is the highest limit a container may request for this
+	// resource; a pod whose container exceeds it is rejected during Validate.
+	MaxLimit resource.Quantity
+	// DefaultLimit, if non-zero, is injected into any container that omits a limit for
+	// this resource.
+	DefaultLimit resource.Quantity
+	// DefaultRequest, if non-zero, is injected into any container that omits a request
+	// for this resource.
+	DefaultRequest resource.Quantity
+}
+
+func (obj *ClusterResourceOverrideConfig) GetObjectKind() schema.ObjectKind { return &obj.TypeMeta }
@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/quota/apiserver/admission/apis/clusterresourceoverride"
+)
+
+// Validate verifies that the given ClusterResourceOverrideConfig is well formed. It returns
+// a ValidationErrorList describing any problems found; a config with none of the three
+// override ratios set and no ContainerResourceBounds is rejected as a no-op misconfiguration.
+func Validate(config *clusterresourceoverride.ClusterResourceOverrideConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.LimitCPUToMemoryPercent == 0 && config.CPURequestToLimitPercent == 0 && config.MemoryRequestToLimitPercent == 0 && config.ContainerResourceBounds == nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath(""), config, "one of limitCPUToMemoryPercent, cpuRequestToLimitPercent, memoryRequestToLimitPercent, or containerResourceBounds must be set"))
+	}
+
+	allErrs = append(allErrs, validatePercent(field.NewPath("limitCPUToMemoryPercent"), config.LimitCPUToMemoryPercent)...)
+	allErrs = append(allErrs, validatePercent(field.NewPath("cpuRequestToLimitPercent"), config.CPURequestToLimitPercent)...)
+	allErrs = append(allErrs, validatePercent(field.NewPath("memoryRequestToLimitPercent"), config.MemoryRequestToLimitPercent)...)
+	allErrs = append(allErrs, validatePercent(field.NewPath("ephemeralStorageRequestToLimitPercent"), config.EphemeralStorageRequestToLimitPercent)...)
+
+	extendedResourceFldPath := field.NewPath("extendedResourceRequestToLimitPercent")
+	for name, percent := range config.ExtendedResourceRequestToLimitPercent {
+		allErrs = append(allErrs, validatePercent(extendedResourceFldPath.Key(name), percent)...)
+	}
+
+	if config.ContainerResourceBounds != nil {
+		allErrs = append(allErrs, validateContainerResourceBounds(field.NewPath("containerResourceBounds"), config.ContainerResourceBounds)...)
+	}
+
+	switch config.Mode {
+	case "", clusterresourceoverride.ModeEnforce, clusterresourceoverride.ModeWarn, clusterresourceoverride.ModeAudit:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("mode"), config.Mode, []string{string(clusterresourceoverride.ModeEnforce), string(clusterresourceoverride.ModeWarn), string(clusterresourceoverride.ModeAudit)}))
+	}
+
+	return allErrs
+}
+
+func validateContainerResourceBounds(fldPath *field.Path, bounds *clusterresourceoverride.ContainerResourceBounds) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateResourceBound(fldPath.Child("cpu"), bounds.CPU)...)
+	allErrs = append(allErrs, validateResourceBound(fldPath.Child("memory"), bounds.Memory)...)
+	return allErrs
+}
+
+// validateResourceBound checks that a default limit/request, when set, does not itself
+// exceed the max limit the plugin would otherwise reject the pod for.
+func validateResourceBound(fldPath *field.Path, bound *clusterresourceoverride.ResourceBound) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if bound == nil {
+		return allErrs
+	}
+	if !bound.MaxLimit.IsZero() {
+		if !bound.DefaultLimit.IsZero() && bound.DefaultLimit.Cmp(bound.MaxLimit) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaultLimit"), bound.DefaultLimit.String(), "must not be greater than maxLimit"))
+		}
+		if !bound.DefaultRequest.IsZero() && bound.DefaultRequest.Cmp(bound.MaxLimit) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaultRequest"), bound.DefaultRequest.String(), "must not be greater than maxLimit"))
+		}
+	}
+	return allErrs
+}
+
+// validatePercent ensures a configured ratio falls within 0-100; a ratio of 0 means
+// "do not override" and is always allowed.
+func validatePercent(fldPath *field.Path, percent int64) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if percent < 0 || percent > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath, percent, fmt.Sprintf("must be between 0 and 100")))
+	}
+	return allErrs
+}
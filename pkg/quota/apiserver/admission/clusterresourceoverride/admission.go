@@ -0,0 +1,613 @@
+package clusterresourceoverride
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
+	kapi "k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/client/listers/core/internalversion"
+
+	configapilatest "github.com/openshift/origin/pkg/cmd/server/apis/config/latest"
+	projectcache "github.com/openshift/origin/pkg/project/cache"
+	"github.com/openshift/origin/pkg/quota/apiserver/admission/apis/clusterresourceoverride"
+	"github.com/openshift/origin/pkg/quota/apiserver/admission/apis/clusterresourceoverride/validation"
+)
+
+const (
+	clusterResourceOverrideAnnotation = "quota.openshift.io/cluster-resource-override-enabled"
+	pluginName                        = "ClusterResourceOverride"
+
+	// Per-namespace annotations let a project opt in to its own override ratios instead
+	// of (or as well as) the cluster-wide ones configured on the plugin. Any one of these
+	// may be set independently of the others; an unset annotation falls back to the
+	// cluster-wide value for that ratio.
+	namespaceCPURequestToLimitAnnotation    = "quota.openshift.io/cluster-resource-override-cpu-request-to-limit-percent"
+	namespaceMemoryRequestToLimitAnnotation = "quota.openshift.io/cluster-resource-override-memory-request-to-limit-percent"
+	namespaceLimitCPUToMemoryAnnotation     = "quota.openshift.io/cluster-resource-override-limit-cpu-to-memory-percent"
+
+	// wouldSetAnnotation is stamped on a pod in ModeWarn/ModeAudit with a summary of what
+	// the plugin would have changed, since neither mode mutates the pod itself.
+	wouldSetAnnotation = "quota.openshift.io/cluster-resource-override-would-set"
+
+	// cpuBaseScaleFactor converts a byte quantity of memory limit into the milliCPU
+	// limit it implies: 1000 milliCPU per 1GiB of memory.
+	cpuBaseScaleFactor = 1000.0 / (1024.0 * 1024.0 * 1024.0)
+
+	// minCPURequestMilli and minMemoryRequestBytes are the smallest request values the
+	// plugin will ever produce; below this, the scheduler can't reason about the pod.
+	minCPURequestMilli    = int64(1)
+	minMemoryRequestBytes = int64(1024 * 1024)
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(pluginName, func(config io.Reader) (admission.Interface, error) {
+		pluginConfig, err := ReadConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		if pluginConfig == nil {
+			klog.Infof("Admission plugin %q is not configured so it will be disabled.", pluginName)
+			return nil, nil
+		}
+		return newClusterResourceOverride(pluginConfig)
+	})
+}
+
+// ReadConfig reads and validates the ClusterResourceOverride plugin configuration from the
+// given reader. A nil reader yields a nil config: no config means the plugin is disabled.
+func ReadConfig(configFile io.Reader) (*clusterresourceoverride.ClusterResourceOverrideConfig, error) {
+	if configFile == nil {
+		return nil, nil
+	}
+	config := &clusterresourceoverride.ClusterResourceOverrideConfig{}
+	if err := configapilatest.ReadYAMLInto(configFile, config); err != nil {
+		return nil, err
+	}
+	if errs := validation.Validate(config); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return config, nil
+}
+
+// internalConfig is the plugin's own runtime representation of the cluster-wide
+// ClusterResourceOverrideConfig; it exists so that Admit doesn't re-derive the same
+// conversions (defaulting the mode, keying extended resources and bounds by resource
+// name) on every pod.
+type internalConfig struct {
+	limitCPUToMemoryPercent               int64
+	cpuRequestToLimitPercent              int64
+	memoryRequestToLimitPercent           int64
+	ephemeralStorageRequestToLimitPercent int64
+	extendedResourceRequestToLimitPercent map[kapi.ResourceName]int64
+	containerBounds                       map[kapi.ResourceName]clusterresourceoverride.ResourceBound
+	mode                                  clusterresourceoverride.OverrideMode
+}
+
+func newInternalConfig(config *clusterresourceoverride.ClusterResourceOverrideConfig) *internalConfig {
+	if config == nil {
+		return nil
+	}
+	internal := &internalConfig{
+		limitCPUToMemoryPercent:               config.LimitCPUToMemoryPercent,
+		cpuRequestToLimitPercent:              config.CPURequestToLimitPercent,
+		memoryRequestToLimitPercent:           config.MemoryRequestToLimitPercent,
+		ephemeralStorageRequestToLimitPercent: config.EphemeralStorageRequestToLimitPercent,
+		mode:                                  config.Mode,
+	}
+	if internal.mode == "" {
+		internal.mode = clusterresourceoverride.ModeEnforce
+	}
+	if len(config.ExtendedResourceRequestToLimitPercent) > 0 {
+		internal.extendedResourceRequestToLimitPercent = map[kapi.ResourceName]int64{}
+		for name, percent := range config.ExtendedResourceRequestToLimitPercent {
+			internal.extendedResourceRequestToLimitPercent[kapi.ResourceName(name)] = percent
+		}
+	}
+	if bounds := config.ContainerResourceBounds; bounds != nil {
+		internal.containerBounds = map[kapi.ResourceName]clusterresourceoverride.ResourceBound{}
+		if bounds.CPU != nil {
+			internal.containerBounds[kapi.ResourceCPU] = *bounds.CPU
+		}
+		if bounds.Memory != nil {
+			internal.containerBounds[kapi.ResourceMemory] = *bounds.Memory
+		}
+	}
+	return internal
+}
+
+type clusterResourceOverridePlugin struct {
+	*admission.Handler
+	config            *internalConfig
+	ProjectCache      *projectcache.ProjectCache
+	limitRangesLister internalversion.LimitRangeLister
+	eventRecorder     record.EventRecorder
+}
+
+var _ admission.MutationInterface = &clusterResourceOverridePlugin{}
+var _ admission.ValidationInterface = &clusterResourceOverridePlugin{}
+
+func newClusterResourceOverride(config *clusterresourceoverride.ClusterResourceOverrideConfig) (admission.Interface, error) {
+	return &clusterResourceOverridePlugin{
+		Handler: admission.NewHandler(admission.Create),
+		config:  newInternalConfig(config),
+	}, nil
+}
+
+// SetProjectCache satisfies the oadmission.WantsProjectCache interface so the plugin is
+// given a cache to consult for the per-namespace override-disabling annotation.
+func (d *clusterResourceOverridePlugin) SetProjectCache(cache *projectcache.ProjectCache) {
+	d.ProjectCache = cache
+}
+
+func (d *clusterResourceOverridePlugin) SetInternalLimitRangeLister(lister internalversion.LimitRangeLister) {
+	d.limitRangesLister = lister
+}
+
+// SetEventRecorder satisfies oadmission.WantsEventRecorder so the plugin can surface what it
+// changed on a pod as a normal Event instead of only as a metric; a nil recorder (e.g. in
+// tests that don't care about events) simply leaves admission decisions unreported.
+func (d *clusterResourceOverridePlugin) SetEventRecorder(recorder record.EventRecorder) {
+	d.eventRecorder = recorder
+}
+
+func (d *clusterResourceOverridePlugin) ValidateInitialization() error {
+	if d.ProjectCache == nil {
+		return fmt.Errorf("%s needs a project cache", pluginName)
+	}
+	if d.limitRangesLister == nil {
+		return fmt.Errorf("%s needs a limit range lister", pluginName)
+	}
+	return nil
+}
+
+// Admit computes the requests and limits this plugin wants a pod's containers to carry,
+// then, in ModeEnforce, rewrites them in place. Validate below re-derives the same values and rejects the
+// pod if anything mutated it again, which is how the rest of the chain (e.g. LimitRanger)
+// is allowed to further tighten values without this plugin fighting it on update.
+func (d *clusterResourceOverridePlugin) Admit(attr admission.Attributes) error {
+	config, ignore, err := d.resolveConfig(attr)
+	if err != nil {
+		return err
+	} else if ignore {
+		return nil
+	}
+
+	pod, ok := attr.GetObject().(*kapi.Pod)
+	if !ok {
+		return nil
+	}
+
+	original := pod.DeepCopy()
+	floors := d.namespaceLimitFloors(attr.GetNamespace())
+	flooredResources := map[kapi.ResourceName]bool{}
+
+	// In ModeWarn/ModeAudit the overrides are computed against a scratch copy so the pod
+	// being admitted is never actually touched; in ModeEnforce they're applied directly.
+	target := pod
+	if config.mode != clusterresourceoverride.ModeEnforce {
+		target = pod.DeepCopy()
+	}
+
+	for i := range target.Spec.InitContainers {
+		applyContainerResourceBounds(&target.Spec.InitContainers[i], config, floors, flooredResources)
+		overrideContainer(&target.Spec.InitContainers[i], config, floors, flooredResources)
+	}
+	for i := range target.Spec.Containers {
+		applyContainerResourceBounds(&target.Spec.Containers[i], config, floors, flooredResources)
+		overrideContainer(&target.Spec.Containers[i], config, floors, flooredResources)
+	}
+
+	if config.mode != clusterresourceoverride.ModeEnforce && !reflect.DeepEqual(target.Spec, original.Spec) {
+		recordWouldSetAnnotation(pod, target, config.mode)
+	}
+
+	d.recordAdmission(attr.GetNamespace(), config.mode, target, original, flooredResources)
+	return nil
+}
+
+// recordWouldSetAnnotation stamps pod, the object actually being admitted, with a summary
+// of what target's resources were recomputed to; pod itself is never mutated to match
+// target in ModeWarn/ModeAudit, so this annotation is the only client-visible record of
+// what enforce mode would have done. ModeWarn additionally logs a server-side warning for
+// an operator watching the apiserver logs; this vendored apiserver predates the structured
+// client-facing admission-warnings mechanism, so there is no way to surface this to the
+// user submitting the pod at request time.
+func recordWouldSetAnnotation(pod, target *kapi.Pod, mode clusterresourceoverride.OverrideMode) {
+	summary := describeWouldSet(target)
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[wouldSetAnnotation] = summary
+	if mode == clusterresourceoverride.ModeWarn {
+		klog.Warningf("%s: pod %s/%s would have its resources overridden: %s", pluginName, pod.Namespace, pod.Name, summary)
+	}
+}
+
+// describeWouldSet summarizes the cpu/memory requests each container in pod ended up with,
+// in "container:cpu=value,memory=value" form, semicolon-separated across containers.
+func describeWouldSet(pod *kapi.Pod) string {
+	var parts []string
+	for _, container := range append(append([]kapi.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		cpu := container.Resources.Requests[kapi.ResourceCPU]
+		memory := container.Resources.Requests[kapi.ResourceMemory]
+		parts = append(parts, fmt.Sprintf("%s:cpu=%s,memory=%s", container.Name, cpu.String(), memory.String()))
+	}
+	return strings.Join(parts, ";")
+}
+
+// recordAdmission reports the outcome of Admit to the admissionsTotal/adjustment-ratio
+// metrics and, if the pod ended up different from what was submitted, emits a single Event
+// summarizing the change so a user inspecting `oc describe pod` can see why their requests
+// don't match what they asked for. In ModeWarn/ModeAudit no Event is emitted, since the pod
+// wasn't actually changed; the would-set annotation is the record for those modes.
+func (d *clusterResourceOverridePlugin) recordAdmission(namespace string, mode clusterresourceoverride.OverrideMode, target, original *kapi.Pod, flooredResources map[kapi.ResourceName]bool) {
+	mutated := !reflect.DeepEqual(target.Spec, original.Spec)
+
+	decision := "unchanged"
+	if mutated {
+		decision = "overridden"
+		if mode != clusterresourceoverride.ModeEnforce {
+			decision = "would-override"
+		}
+	}
+	admissionsTotal.WithLabelValues(namespace, decision).Inc()
+
+	for resourceName := range flooredResources {
+		limitRangeFloorAppliedTotal.WithLabelValues(string(resourceName)).Inc()
+	}
+
+	recordContainerAdjustmentRatios(original.Spec.InitContainers, target.Spec.InitContainers)
+	recordContainerAdjustmentRatios(original.Spec.Containers, target.Spec.Containers)
+
+	if mutated && mode == clusterresourceoverride.ModeEnforce && d.eventRecorder != nil {
+		d.eventRecorder.Eventf(target, corev1.EventTypeNormal, "ClusterResourceOverride", "Overrode container resource requests from %s to %s", describeWouldSet(original), describeWouldSet(target))
+	}
+}
+
+// recordContainerAdjustmentRatios observes, for each container present in both slices, how
+// far the plugin moved its cpu and memory requests from what was originally submitted.
+func recordContainerAdjustmentRatios(original, adjusted []kapi.Container) {
+	for i := range adjusted {
+		if i >= len(original) {
+			return
+		}
+		recordRequestAdjustment(cpuRequestAdjustmentRatio, original[i].Resources.Requests[kapi.ResourceCPU], adjusted[i].Resources.Requests[kapi.ResourceCPU])
+		recordRequestAdjustment(memoryRequestAdjustmentRatio, original[i].Resources.Requests[kapi.ResourceMemory], adjusted[i].Resources.Requests[kapi.ResourceMemory])
+	}
+}
+
+func recordRequestAdjustment(histogram prometheus.Histogram, original, adjusted resource.Quantity) {
+	if original.Cmp(adjusted) == 0 {
+		return
+	}
+	recordAdjustmentRatio(histogram, original.MilliValue(), adjusted.MilliValue())
+}
+
+func (d *clusterResourceOverridePlugin) Validate(attr admission.Attributes) error {
+	config, ignore, err := d.resolveConfig(attr)
+	if err != nil {
+		return err
+	} else if ignore {
+		return nil
+	}
+
+	pod, ok := attr.GetObject().(*kapi.Pod)
+	if !ok {
+		return nil
+	}
+
+	// ModeWarn/ModeAudit never rewrite the pod, so the floors/bounds Admit would otherwise
+	// have enforced by construction can't be re-checked here without rejecting pods that
+	// enforce mode was never actually asked to fix.
+	if config.mode != clusterresourceoverride.ModeEnforce {
+		return nil
+	}
+
+	floors := d.namespaceLimitFloors(attr.GetNamespace())
+
+	for i := range pod.Spec.InitContainers {
+		if err := validateContainer(&pod.Spec.InitContainers[i], config, floors); err != nil {
+			return err
+		}
+	}
+	for i := range pod.Spec.Containers {
+		if err := validateContainer(&pod.Spec.Containers[i], config, floors); err != nil {
+			return err
+		}
+	}
+
+	expected := pod.DeepCopy()
+	ignored := map[kapi.ResourceName]bool{}
+	for i := range expected.Spec.InitContainers {
+		applyContainerResourceBounds(&expected.Spec.InitContainers[i], config, floors, ignored)
+		overrideContainer(&expected.Spec.InitContainers[i], config, floors, ignored)
+	}
+	for i := range expected.Spec.Containers {
+		applyContainerResourceBounds(&expected.Spec.Containers[i], config, floors, ignored)
+		overrideContainer(&expected.Spec.Containers[i], config, floors, ignored)
+	}
+	if !reflect.DeepEqual(expected.Spec, pod.Spec) {
+		return fmt.Errorf("%s: pod %q resources differ from the values computed for namespace %q", pluginName, pod.Name, attr.GetNamespace())
+	}
+	return nil
+}
+
+// resolveConfig decides whether this request should be touched at all and, if so, returns
+// the effective internalConfig for its namespace: the cluster-wide config with any of the
+// three ratios replaced by a valid per-namespace annotation override.
+func (d *clusterResourceOverridePlugin) resolveConfig(attr admission.Attributes) (*internalConfig, bool, error) {
+	if d.config == nil {
+		return nil, true, nil
+	}
+	if attr.GetResource().GroupResource() != kapi.Resource("pods") || attr.GetSubresource() != "" {
+		return nil, true, nil
+	}
+	if _, ok := attr.GetObject().(*kapi.Pod); !ok {
+		return nil, true, nil
+	}
+
+	ns, err := d.ProjectCache.GetNamespace(attr.GetNamespace())
+	if err != nil {
+		return nil, false, err
+	}
+	if ns.Annotations[clusterResourceOverrideAnnotation] == "false" {
+		return nil, true, nil
+	}
+
+	config, err := namespaceOverriddenConfig(d.config, ns)
+	if err != nil {
+		return nil, false, err
+	}
+	return config, false, nil
+}
+
+// namespaceOverriddenConfig returns a copy of cluster with any ratio replaced by a valid
+// value found in the namespace's override annotations. An annotation that is present but
+// fails to parse as an integer in [0, 100] is an admission error rather than a silent
+// fallback to the cluster-wide value, so a typo in the annotation doesn't quietly apply
+// the wrong ratio to every pod in the namespace.
+func namespaceOverriddenConfig(cluster *internalConfig, ns *corev1.Namespace) (*internalConfig, error) {
+	resolved := *cluster
+
+	overrides := []struct {
+		annotation string
+		dest       *int64
+	}{
+		{namespaceLimitCPUToMemoryAnnotation, &resolved.limitCPUToMemoryPercent},
+		{namespaceCPURequestToLimitAnnotation, &resolved.cpuRequestToLimitPercent},
+		{namespaceMemoryRequestToLimitAnnotation, &resolved.memoryRequestToLimitPercent},
+	}
+
+	for _, override := range overrides {
+		raw, ok := ns.Annotations[override.annotation]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < 0 || value > 100 {
+			return nil, fmt.Errorf("%s: namespace %q annotation %s must be an integer between 0 and 100, got %q", pluginName, ns.Name, override.annotation, raw)
+		}
+		*override.dest = value
+	}
+
+	return &resolved, nil
+}
+
+// namespaceLimitFloors returns, per resource, the smallest Min among the LimitRange
+// container-type entries in this namespace; any value this plugin computes is raised to
+// at least that floor.
+func (d *clusterResourceOverridePlugin) namespaceLimitFloors(namespace string) map[kapi.ResourceName]resource.Quantity {
+	floors := map[kapi.ResourceName]resource.Quantity{}
+
+	ranges, err := d.limitRangesLister.LimitRanges(namespace).List(labels.Everything())
+	if err != nil {
+		return floors
+	}
+	for _, limitRange := range ranges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != kapi.LimitTypeContainer {
+				continue
+			}
+			for resourceName, min := range item.Min {
+				if existing, ok := floors[resourceName]; !ok || min.Cmp(existing) < 0 {
+					floors[resourceName] = min
+				}
+			}
+		}
+	}
+	return floors
+}
+
+func overrideContainer(container *kapi.Container, config *internalConfig, floors map[kapi.ResourceName]resource.Quantity, flooredResources map[kapi.ResourceName]bool) {
+	setRequest := func(resourceName kapi.ResourceName, q resource.Quantity) {
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = kapi.ResourceList{}
+		}
+		container.Resources.Requests[resourceName] = q
+	}
+
+	memLimit, hasMemLimit := container.Resources.Limits[kapi.ResourceMemory]
+	if hasMemLimit && !memLimit.IsZero() {
+		if config.limitCPUToMemoryPercent > 0 {
+			cpuLimit := memoryToCPU(memLimit, config.limitCPUToMemoryPercent)
+			if applyFloor(&cpuLimit, floors[kapi.ResourceCPU]) {
+				flooredResources[kapi.ResourceCPU] = true
+			}
+			container.Resources.Limits[kapi.ResourceCPU] = cpuLimit
+		}
+
+		if config.memoryRequestToLimitPercent > 0 {
+			// Memory requests are rounded down to a whole MiB (MB for decimal quantities).
+			mod := int64(1000 * 1000)
+			if memLimit.Format == resource.BinarySI {
+				mod = 1024 * 1024
+			}
+			value := int64(float64(memLimit.Value()) * float64(config.memoryRequestToLimitPercent) / 100)
+			value -= value % mod
+			if value < minMemoryRequestBytes {
+				value = minMemoryRequestBytes
+			}
+			memRequest := *resource.NewQuantity(value, memLimit.Format)
+			if applyFloor(&memRequest, floors[kapi.ResourceMemory]) {
+				flooredResources[kapi.ResourceMemory] = true
+			}
+			setRequest(kapi.ResourceMemory, memRequest)
+		}
+
+		if config.cpuRequestToLimitPercent > 0 {
+			if cpuLimit, ok := container.Resources.Limits[kapi.ResourceCPU]; ok {
+				cpuRequest := percentageOfCPUQuantity(cpuLimit, config.cpuRequestToLimitPercent, minCPURequestMilli)
+				if applyFloor(&cpuRequest, floors[kapi.ResourceCPU]) {
+					flooredResources[kapi.ResourceCPU] = true
+				}
+				setRequest(kapi.ResourceCPU, cpuRequest)
+			}
+		}
+	}
+
+	// Ephemeral-storage and extended-resource overrides are independent of whether the
+	// container even has a memory limit: unlike cpu, neither is ever derived from memory,
+	// so a container that only pins e.g. nvidia.com/gpu still gets its request computed.
+	if config.ephemeralStorageRequestToLimitPercent > 0 {
+		overrideRequestFromLimit(container, kapi.ResourceEphemeralStorage, config.ephemeralStorageRequestToLimitPercent, floors, flooredResources)
+	}
+
+	for resourceName, percent := range config.extendedResourceRequestToLimitPercent {
+		if percent > 0 {
+			overrideRequestFromLimit(container, resourceName, percent, floors, flooredResources)
+		}
+	}
+}
+
+// overrideRequestFromLimit sets container's request for resourceName to percent% of its
+// limit for that resource, if a limit is present; it is a no-op otherwise. Used for any
+// resource, beyond cpu and memory, whose request the plugin derives directly from its own
+// limit rather than from another resource's limit.
+func overrideRequestFromLimit(container *kapi.Container, resourceName kapi.ResourceName, percent int64, floors map[kapi.ResourceName]resource.Quantity, flooredResources map[kapi.ResourceName]bool) {
+	limit, ok := container.Resources.Limits[resourceName]
+	if !ok {
+		return
+	}
+	request := percentageOfQuantity(limit, percent, 0)
+	if applyFloor(&request, floors[resourceName]) {
+		flooredResources[resourceName] = true
+	}
+	if container.Resources.Requests == nil {
+		container.Resources.Requests = kapi.ResourceList{}
+	}
+	container.Resources.Requests[resourceName] = request
+}
+
+func validateContainer(container *kapi.Container, config *internalConfig, floors map[kapi.ResourceName]resource.Quantity) error {
+	// validateContainer checks the namespace's LimitRange floors and the configured max
+	// limits; Validate separately re-derives the overrides and compares them to the pod.
+	for resourceName, floor := range floors {
+		if actual, ok := container.Resources.Requests[resourceName]; ok && actual.Cmp(floor) < 0 {
+			return fmt.Errorf("%s: container %q requests %s below the namespace minimum %s", pluginName, container.Name, resourceName, floor.String())
+		}
+	}
+
+	for resourceName, bound := range config.containerBounds {
+		if bound.MaxLimit.IsZero() {
+			continue
+		}
+		if actual, ok := container.Resources.Limits[resourceName]; ok && actual.Cmp(bound.MaxLimit) > 0 {
+			return fmt.Errorf("%s: container %q limit %s of %s exceeds the maximum allowed %s", pluginName, container.Name, resourceName, actual.String(), bound.MaxLimit.String())
+		}
+	}
+	return nil
+}
+
+// applyContainerResourceBounds injects the configured default limit/request for any
+// resource a container omits, before the ratio-based overrides in overrideContainer run.
+// This lets operators guarantee every container ends up with a limit for a bounded
+// resource, even one that never specified resources at all. Defaults are still raised to
+// the namespace's LimitRange floors like every other value the plugin computes, since
+// otherwise a container that never specified its own request could end up rejected by
+// Validate for a floor violation the plugin itself introduced.
+func applyContainerResourceBounds(container *kapi.Container, config *internalConfig, floors map[kapi.ResourceName]resource.Quantity, flooredResources map[kapi.ResourceName]bool) {
+	if len(config.containerBounds) == 0 {
+		return
+	}
+
+	for resourceName, bound := range config.containerBounds {
+		if !bound.DefaultLimit.IsZero() {
+			if container.Resources.Limits == nil {
+				container.Resources.Limits = kapi.ResourceList{}
+			}
+			if _, ok := container.Resources.Limits[resourceName]; !ok {
+				container.Resources.Limits[resourceName] = bound.DefaultLimit
+			}
+		}
+		if !bound.DefaultRequest.IsZero() {
+			if container.Resources.Requests == nil {
+				container.Resources.Requests = kapi.ResourceList{}
+			}
+			if _, ok := container.Resources.Requests[resourceName]; !ok {
+				request := bound.DefaultRequest
+				if applyFloor(&request, floors[resourceName]) {
+					flooredResources[resourceName] = true
+				}
+				container.Resources.Requests[resourceName] = request
+			}
+		}
+	}
+}
+
+// memoryToCPU derives a CPU limit, in millicores, from a memory limit using the
+// configured ratio: ratio=100 means 1000 millicores of CPU limit per 1GiB of memory.
+// Fractional millicores are truncated.
+func memoryToCPU(memLimit resource.Quantity, ratioPercent int64) resource.Quantity {
+	milli := int64(float64(memLimit.Value()) * cpuBaseScaleFactor * float64(ratioPercent) / 100)
+	if milli < minCPURequestMilli {
+		milli = minCPURequestMilli
+	}
+	return *resource.NewMilliQuantity(milli, resource.DecimalSI)
+}
+
+// percentageOfQuantity returns ratioPercent% of q, rounded up, never going below floor
+// (expressed in q's own base unit - bytes for memory and most other resources).
+func percentageOfQuantity(q resource.Quantity, ratioPercent int64, floor int64) resource.Quantity {
+	value := int64(math.Ceil(float64(q.Value()) * float64(ratioPercent) / 100))
+	if value < floor {
+		value = floor
+	}
+	return *resource.NewQuantity(value, q.Format)
+}
+
+// percentageOfCPUQuantity is percentageOfQuantity specialized for cpu quantities, which are
+// routinely sub-core (e.g. "50m"); q.Value() rounds those up to the nearest whole core before
+// the ratio is even applied, so cpu must be computed in millicores throughout instead. floor
+// is expressed in millicores.
+func percentageOfCPUQuantity(q resource.Quantity, ratioPercent int64, floorMilli int64) resource.Quantity {
+	milli := int64(math.Ceil(float64(q.MilliValue()) * float64(ratioPercent) / 100))
+	if milli < floorMilli {
+		milli = floorMilli
+	}
+	return *resource.NewMilliQuantity(milli, resource.DecimalSI)
+}
+
+// applyFloor raises *q to floor if it falls below it, reporting whether it did so; callers
+// use the return value to count how often a namespace's LimitRange minimum, rather than the
+// plugin's own ratio math, determined the final value.
+func applyFloor(q *resource.Quantity, floor resource.Quantity) bool {
+	if floor.IsZero() {
+		return false
+	}
+	if q.Cmp(floor) < 0 {
+		*q = floor
+		return true
+	}
+	return false
+}
@@ -16,6 +16,7 @@ import (
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	kapi "k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/client/listers/core/internalversion"
 
@@ -43,6 +44,13 @@ cpuRequestToLimitPercent: 200
 	invalidConfig2 = `
 apiVersion: v1
 kind: ClusterResourceOverrideConfig
+`
+	boundsOnlyConfig = `
+apiVersion: v1
+kind: ClusterResourceOverrideConfig
+containerResourceBounds:
+  cpu:
+    maxLimit: "4"
 `
 )
 
@@ -95,6 +103,9 @@ func TestConfigReader(t *testing.T) {
 			config:        bytes.NewReader([]byte(invalidConfig2)),
 			expectInvalid: true,
 			expectErr:     true,
+		}, {
+			name:   "a bounds-only config with no ratios set is valid",
+			config: bytes.NewReader([]byte(boundsOnlyConfig)),
 		},
 	}
 	for _, test := range tests {
@@ -112,7 +123,7 @@ func TestConfigReader(t *testing.T) {
 			}
 		}
 		if config != nil {
-			if test.expectedConfig != nil && *test.expectedConfig != *config {
+			if test.expectedConfig != nil && !reflect.DeepEqual(*test.expectedConfig, *config) {
 				t.Errorf("%s: expected %v from reader, but got %v", test.name, test.expectErr, config)
 			}
 			if err := validation.Validate(config); test.expectInvalid && len(err) == 0 {
@@ -132,8 +143,12 @@ func TestLimitRequestAdmission(t *testing.T) {
 		expectedMemRequest resource.Quantity
 		expectedCpuLimit   resource.Quantity
 		expectedCpuRequest resource.Quantity
-		namespace          *corev1.Namespace
-		namespaceLimits    []*kapi.LimitRange
+		// expectedStorageRequest is only checked when non-nil, since most cases in this
+		// table don't configure ephemeral-storage overrides at all.
+		expectedStorageRequest *resource.Quantity
+		namespace              *corev1.Namespace
+		namespaceLimits        []*kapi.LimitRange
+		expectWouldSet         bool
 	}{
 		{
 			name:               "ignore pods that have no memory limit specified",
@@ -307,17 +322,52 @@ func TestLimitRequestAdmission(t *testing.T) {
 			namespace:          fakeNamespace(true),
 		},
 		{
-			name:               "test only containers types are considered with namespace limits",
-			config:             testConfig(100, 50, 50),
-			pod:                testPod("1Gi", "0", "2000m", "0"),
-			expectedMemRequest: resource.MustParse("512Mi"),
-			expectedCpuLimit:   resource.MustParse("1"),
-			expectedCpuRequest: resource.MustParse("500m"),
-			namespace:          fakeNamespace(true),
+			name: "test only containers types are considered with namespace limits",
+			config: &clusterresourceoverride.ClusterResourceOverrideConfig{
+				LimitCPUToMemoryPercent:               100,
+				CPURequestToLimitPercent:              50,
+				MemoryRequestToLimitPercent:           50,
+				EphemeralStorageRequestToLimitPercent: 10,
+			},
+			pod:                    testPodWithEphemeralStorage("1Gi", "0", "2000m", "0", "2Gi"),
+			expectedMemRequest:     resource.MustParse("512Mi"),
+			expectedCpuLimit:       resource.MustParse("1"),
+			expectedCpuRequest:     resource.MustParse("500m"),
+			expectedStorageRequest: storageQuantityPtr("1567Mi"),
+			namespace:              fakeNamespace(true),
 			namespaceLimits: []*kapi.LimitRange{
-				fakeMinStorageLimitRange("1567Mi"),
+				// A PVC-type storage floor must be ignored: only container-type
+				// LimitRange entries bound what this plugin computes.
+				fakeMinStorageLimitRange("99Gi"),
+				fakeMinEphemeralStorageLimitRange("1567Mi"),
 			},
 		},
+		{
+			name: "warn mode computes but does not mutate",
+			config: &clusterresourceoverride.ClusterResourceOverrideConfig{
+				MemoryRequestToLimitPercent: 50,
+				Mode:                        clusterresourceoverride.ModeWarn,
+			},
+			pod:                testPod("1Gi", "0", "0", "0"),
+			expectedMemRequest: resource.MustParse("0"),
+			expectedCpuLimit:   resource.MustParse("0"),
+			expectedCpuRequest: resource.MustParse("0"),
+			namespace:          fakeNamespace(true),
+			expectWouldSet:     true,
+		},
+		{
+			name: "audit mode computes but does not mutate",
+			config: &clusterresourceoverride.ClusterResourceOverrideConfig{
+				MemoryRequestToLimitPercent: 50,
+				Mode:                        clusterresourceoverride.ModeAudit,
+			},
+			pod:                testPod("1Gi", "0", "0", "0"),
+			expectedMemRequest: resource.MustParse("0"),
+			expectedCpuLimit:   resource.MustParse("0"),
+			expectedCpuRequest: resource.MustParse("0"),
+			namespace:          fakeNamespace(true),
+			expectWouldSet:     true,
+		},
 	}
 
 	for _, test := range tests {
@@ -342,13 +392,22 @@ func TestLimitRequestAdmission(t *testing.T) {
 				t.Fatalf("%s: admission controller returned error: %v", test.name, err)
 			}
 
-			if !reflect.DeepEqual(test.pod, clone) {
+			if !reflect.DeepEqual(test.pod.Spec, clone.Spec) {
 				attrs := admission.NewAttributesRecord(clone, nil, schema.GroupVersionKind{}, test.namespace.Name, "name", kapi.Resource("pods").WithVersion("version"), "", admission.Create, fakeUser())
 				if err = c.(admission.ValidationInterface).Validate(attrs); err == nil {
 					t.Fatalf("%s: admission controller returned no error, but should", test.name)
 				}
 			}
 
+			if test.expectWouldSet {
+				if !reflect.DeepEqual(test.pod.Spec, clone.Spec) {
+					t.Errorf("%s: pod should not be mutated in warn/audit mode", test.name)
+				}
+				if test.pod.Annotations[wouldSetAnnotation] == "" {
+					t.Errorf("%s: expected %s annotation to be set", test.name, wouldSetAnnotation)
+				}
+			}
+
 			resources := test.pod.Spec.InitContainers[0].Resources // only test one container
 			if actual := resources.Requests[kapi.ResourceMemory]; test.expectedMemRequest.Cmp(actual) != 0 {
 				t.Errorf("%s: memory requests do not match; %v should be %v", test.name, actual, test.expectedMemRequest)
@@ -359,6 +418,11 @@ func TestLimitRequestAdmission(t *testing.T) {
 			if actual := resources.Limits[kapi.ResourceCPU]; test.expectedCpuLimit.Cmp(actual) != 0 {
 				t.Errorf("%s: cpu limits do not match; %v should be %v", test.name, actual, test.expectedCpuLimit)
 			}
+			if test.expectedStorageRequest != nil {
+				if actual := resources.Requests[kapi.ResourceEphemeralStorage]; test.expectedStorageRequest.Cmp(actual) != 0 {
+					t.Errorf("%s: ephemeral-storage requests do not match; %v should be %v", test.name, actual, test.expectedStorageRequest)
+				}
+			}
 
 			resources = test.pod.Spec.Containers[0].Resources // only test one container
 			if actual := resources.Requests[kapi.ResourceMemory]; test.expectedMemRequest.Cmp(actual) != 0 {
@@ -370,6 +434,11 @@ func TestLimitRequestAdmission(t *testing.T) {
 			if actual := resources.Limits[kapi.ResourceCPU]; test.expectedCpuLimit.Cmp(actual) != 0 {
 				t.Errorf("%s: cpu limits do not match; %v should be %v", test.name, actual, test.expectedCpuLimit)
 			}
+			if test.expectedStorageRequest != nil {
+				if actual := resources.Requests[kapi.ResourceEphemeralStorage]; test.expectedStorageRequest.Cmp(actual) != 0 {
+					t.Errorf("%s: ephemeral-storage requests do not match; %v should be %v", test.name, actual, test.expectedStorageRequest)
+				}
+			}
 		})
 	}
 }
@@ -426,6 +495,26 @@ func testPod(memLimit string, memRequest string, cpuLimit string, cpuRequest str
 	}
 }
 
+// testPodWithEphemeralStorage is testPod plus an ephemeral-storage limit on every container,
+// for cases that need to exercise ephemeral-storage overrides alongside cpu/memory ones.
+func testPodWithEphemeralStorage(memLimit, memRequest, cpuLimit, cpuRequest, storageLimit string) *kapi.Pod {
+	pod := testPod(memLimit, memRequest, cpuLimit, cpuRequest)
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Resources.Limits[kapi.ResourceEphemeralStorage] = resource.MustParse(storageLimit)
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Resources.Limits[kapi.ResourceEphemeralStorage] = resource.MustParse(storageLimit)
+	}
+	return pod
+}
+
+// storageQuantityPtr is a convenience for table tests that only set expectedStorageRequest
+// in one or two rows: most rows leave the table's pointer field nil rather than parsing "0".
+func storageQuantityPtr(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
 func fakeUser() user.Info {
 	return &user.DefaultInfo{
 		Name: "testuser",
@@ -491,6 +580,10 @@ func fakeMinStorageLimitRange(limits ...string) *kapi.LimitRange {
 	return fakeMinLimitRange(kapi.LimitTypePersistentVolumeClaim, kapi.ResourceStorage, limits...)
 }
 
+func fakeMinEphemeralStorageLimitRange(limits ...string) *kapi.LimitRange {
+	return fakeMinLimitRange(kapi.LimitTypeContainer, kapi.ResourceEphemeralStorage, limits...)
+}
+
 type fakeLimitRangeLister struct {
 	internalversion.LimitRangeLister
 	namespaceLister fakeLimitRangeNamespaceLister
@@ -508,3 +601,342 @@ func (f fakeLimitRangeLister) LimitRanges(namespace string) internalversion.Limi
 func (f fakeLimitRangeNamespaceLister) List(selector labels.Selector) ([]*kapi.LimitRange, error) {
 	return f.limits, nil
 }
+
+func fakeNamespaceWithOverrides(annotations map[string]string) *corev1.Namespace {
+	ns := fakeNamespace(true)
+	for k, v := range annotations {
+		ns.Annotations[k] = v
+	}
+	return ns
+}
+
+func TestNamespaceOverrideAnnotations(t *testing.T) {
+	tests := []struct {
+		name               string
+		config             *clusterresourceoverride.ClusterResourceOverrideConfig
+		namespace          *corev1.Namespace
+		pod                *kapi.Pod
+		expectAdmitErr     bool
+		expectedMemRequest resource.Quantity
+		expectedCpuLimit   resource.Quantity
+		expectedCpuRequest resource.Quantity
+	}{
+		{
+			name:   "namespace overrides all three ratios",
+			config: testConfig(100, 50, 50),
+			namespace: fakeNamespaceWithOverrides(map[string]string{
+				namespaceLimitCPUToMemoryAnnotation:     "10",
+				namespaceCPURequestToLimitAnnotation:    "10",
+				namespaceMemoryRequestToLimitAnnotation: "10",
+			}),
+			pod:                testPod("1Gi", "0", "0", "0"),
+			expectedMemRequest: resource.MustParse("103Mi"),
+			expectedCpuLimit:   resource.MustParse("100m"),
+			expectedCpuRequest: resource.MustParse("10m"),
+		},
+		{
+			name:   "namespace overrides only memory ratio, cpu falls back to cluster config",
+			config: testConfig(100, 50, 50),
+			namespace: fakeNamespaceWithOverrides(map[string]string{
+				namespaceMemoryRequestToLimitAnnotation: "10",
+			}),
+			pod:                testPod("1Gi", "0", "0", "0"),
+			expectedMemRequest: resource.MustParse("103Mi"),
+			expectedCpuLimit:   resource.MustParse("1"),
+			expectedCpuRequest: resource.MustParse("500m"),
+		},
+		{
+			name:   "out of bounds namespace annotation is an admission error",
+			config: testConfig(100, 50, 50),
+			namespace: fakeNamespaceWithOverrides(map[string]string{
+				namespaceCPURequestToLimitAnnotation: "200",
+			}),
+			pod:            testPod("1Gi", "0", "0", "0"),
+			expectAdmitErr: true,
+		},
+		{
+			name:   "unparsable namespace annotation is an admission error",
+			config: testConfig(100, 50, 50),
+			namespace: fakeNamespaceWithOverrides(map[string]string{
+				namespaceLimitCPUToMemoryAnnotation: "not-a-number",
+			}),
+			pod:            testPod("1Gi", "0", "0", "0"),
+			expectAdmitErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := newClusterResourceOverride(test.config)
+			if err != nil {
+				t.Fatalf("%s: config de/serialize failed: %v", test.name, err)
+			}
+			c.(*clusterResourceOverridePlugin).limitRangesLister = fakeLimitRangeLister{}
+			c.(*clusterResourceOverridePlugin).SetProjectCache(fakeProjectCache(test.namespace))
+
+			attrs := admission.NewAttributesRecord(test.pod, nil, schema.GroupVersionKind{}, test.namespace.Name, "name", kapi.Resource("pods").WithVersion("version"), "", admission.Create, fakeUser())
+			err = c.(admission.MutationInterface).Admit(attrs)
+			if test.expectAdmitErr {
+				if err == nil {
+					t.Fatalf("%s: expected admission error, got none", test.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s: admission controller returned error: %v", test.name, err)
+			}
+
+			resources := test.pod.Spec.Containers[0].Resources
+			if actual := resources.Requests[kapi.ResourceMemory]; test.expectedMemRequest.Cmp(actual) != 0 {
+				t.Errorf("%s: memory requests do not match; %v should be %v", test.name, actual, test.expectedMemRequest)
+			}
+			if actual := resources.Requests[kapi.ResourceCPU]; test.expectedCpuRequest.Cmp(actual) != 0 {
+				t.Errorf("%s: cpu requests do not match; %v should be %v", test.name, actual, test.expectedCpuRequest)
+			}
+			if actual := resources.Limits[kapi.ResourceCPU]; test.expectedCpuLimit.Cmp(actual) != 0 {
+				t.Errorf("%s: cpu limits do not match; %v should be %v", test.name, actual, test.expectedCpuLimit)
+			}
+		})
+	}
+}
+
+func boundedConfig(maxCPU, defaultCPULimit, defaultCPURequest, maxMemory, defaultMemLimit, defaultMemRequest string) *clusterresourceoverride.ClusterResourceOverrideConfig {
+	return &clusterresourceoverride.ClusterResourceOverrideConfig{
+		ContainerResourceBounds: &clusterresourceoverride.ContainerResourceBounds{
+			CPU: &clusterresourceoverride.ResourceBound{
+				MaxLimit:       resource.MustParse(maxCPU),
+				DefaultLimit:   resource.MustParse(defaultCPULimit),
+				DefaultRequest: resource.MustParse(defaultCPURequest),
+			},
+			Memory: &clusterresourceoverride.ResourceBound{
+				MaxLimit:       resource.MustParse(maxMemory),
+				DefaultLimit:   resource.MustParse(defaultMemLimit),
+				DefaultRequest: resource.MustParse(defaultMemRequest),
+			},
+		},
+	}
+}
+
+func TestContainerResourceBounds(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          *clusterresourceoverride.ClusterResourceOverrideConfig
+		pod             *kapi.Pod
+		namespaceLimits []*kapi.LimitRange
+		expectAdmitErr  bool
+		expectValidErr  bool
+		expectedLimit   resource.Quantity
+		expectedRequest resource.Quantity
+	}{
+		{
+			name:            "missing limits get defaults",
+			config:          boundedConfig("4", "1", "500m", "4Gi", "1Gi", "512Mi"),
+			pod:             testBestEffortPod(),
+			expectedLimit:   resource.MustParse("1"),
+			expectedRequest: resource.MustParse("500m"),
+		},
+		{
+			name:            "over-max limit is rejected",
+			config:          boundedConfig("1", "1", "500m", "4Gi", "1Gi", "512Mi"),
+			pod:             testPod("1Gi", "0", "2", "0"),
+			expectValidErr:  true,
+			expectedLimit:   resource.MustParse("2"),
+			expectedRequest: resource.MustParse("0"),
+		},
+		{
+			name:            "defaults respect namespace limit range floor",
+			config:          boundedConfig("4", "1", "100m", "4Gi", "1Gi", "512Mi"),
+			pod:             testBestEffortPod(),
+			namespaceLimits: []*kapi.LimitRange{fakeMinCPULimitRange("250m")},
+			expectedLimit:   resource.MustParse("1"),
+			expectedRequest: resource.MustParse("250m"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := newClusterResourceOverride(test.config)
+			if err != nil {
+				t.Fatalf("%s: config de/serialize failed: %v", test.name, err)
+			}
+			ns := fakeNamespace(true)
+			c.(*clusterResourceOverridePlugin).limitRangesLister = fakeLimitRangeLister{
+				namespaceLister: fakeLimitRangeNamespaceLister{limits: test.namespaceLimits},
+			}
+			c.(*clusterResourceOverridePlugin).SetProjectCache(fakeProjectCache(ns))
+
+			attrs := admission.NewAttributesRecord(test.pod, nil, schema.GroupVersionKind{}, ns.Name, "name", kapi.Resource("pods").WithVersion("version"), "", admission.Create, fakeUser())
+			if err := c.(admission.MutationInterface).Admit(attrs); (err != nil) != test.expectAdmitErr {
+				t.Fatalf("%s: Admit error = %v, expectAdmitErr = %v", test.name, err, test.expectAdmitErr)
+			}
+			if test.expectAdmitErr {
+				return
+			}
+
+			err = c.(admission.ValidationInterface).Validate(attrs)
+			if (err != nil) != test.expectValidErr {
+				t.Fatalf("%s: Validate error = %v, expectValidErr = %v", test.name, err, test.expectValidErr)
+			}
+
+			resources := test.pod.Spec.Containers[0].Resources
+			if actual := resources.Limits[kapi.ResourceCPU]; test.expectedLimit.Cmp(actual) != 0 {
+				t.Errorf("%s: cpu limit does not match; %v should be %v", test.name, actual, test.expectedLimit)
+			}
+			if actual := resources.Requests[kapi.ResourceCPU]; test.expectedRequest.Cmp(actual) != 0 {
+				t.Errorf("%s: cpu request does not match; %v should be %v", test.name, actual, test.expectedRequest)
+			}
+		})
+	}
+}
+
+func testStoragePod(memLimit, storageLimit, gpuLimit string) *kapi.Pod {
+	limits := kapi.ResourceList{
+		kapi.ResourceMemory: resource.MustParse(memLimit),
+	}
+	if storageLimit != "" {
+		limits[kapi.ResourceEphemeralStorage] = resource.MustParse(storageLimit)
+	}
+	if gpuLimit != "" {
+		limits[corev1.ResourceName("nvidia.com/gpu")] = resource.MustParse(gpuLimit)
+	}
+	container := kapi.Container{Resources: kapi.ResourceRequirements{Limits: limits}}
+	return &kapi.Pod{
+		Spec: kapi.PodSpec{
+			InitContainers: []kapi.Container{container},
+			Containers:     []kapi.Container{container},
+		},
+	}
+}
+
+func TestEphemeralStorageAndExtendedResourceOverride(t *testing.T) {
+	tests := []struct {
+		name               string
+		config             *clusterresourceoverride.ClusterResourceOverrideConfig
+		pod                *kapi.Pod
+		namespaceLimits    []*kapi.LimitRange
+		expectedStorageReq resource.Quantity
+		expectedGPURequest resource.Quantity
+	}{
+		{
+			name: "ephemeral storage request is derived from limit",
+			config: &clusterresourceoverride.ClusterResourceOverrideConfig{
+				EphemeralStorageRequestToLimitPercent: 50,
+			},
+			pod:                testStoragePod("1Gi", "2Gi", ""),
+			expectedStorageReq: resource.MustParse("1Gi"),
+		},
+		{
+			name: "ephemeral storage request is raised to the namespace floor",
+			config: &clusterresourceoverride.ClusterResourceOverrideConfig{
+				EphemeralStorageRequestToLimitPercent: 10,
+			},
+			pod:                testStoragePod("1Gi", "2Gi", ""),
+			namespaceLimits:    []*kapi.LimitRange{fakeMinEphemeralStorageLimitRange("1Gi")},
+			expectedStorageReq: resource.MustParse("1Gi"),
+		},
+		{
+			name: "extended resource request is derived from limit",
+			config: &clusterresourceoverride.ClusterResourceOverrideConfig{
+				ExtendedResourceRequestToLimitPercent: map[string]int64{"nvidia.com/gpu": 50},
+			},
+			pod:                testStoragePod("1Gi", "", "4"),
+			expectedGPURequest: resource.MustParse("2"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := newClusterResourceOverride(test.config)
+			if err != nil {
+				t.Fatalf("%s: config de/serialize failed: %v", test.name, err)
+			}
+			ns := fakeNamespace(true)
+			c.(*clusterResourceOverridePlugin).limitRangesLister = fakeLimitRangeLister{
+				namespaceLister: fakeLimitRangeNamespaceLister{limits: test.namespaceLimits},
+			}
+			c.(*clusterResourceOverridePlugin).SetProjectCache(fakeProjectCache(ns))
+
+			attrs := admission.NewAttributesRecord(test.pod, nil, schema.GroupVersionKind{}, ns.Name, "name", kapi.Resource("pods").WithVersion("version"), "", admission.Create, fakeUser())
+			if err := c.(admission.MutationInterface).Admit(attrs); err != nil {
+				t.Fatalf("%s: admission controller returned error: %v", test.name, err)
+			}
+			if err := c.(admission.ValidationInterface).Validate(attrs); err != nil {
+				t.Fatalf("%s: admission controller returned error: %v", test.name, err)
+			}
+
+			resources := test.pod.Spec.Containers[0].Resources
+			if !test.expectedStorageReq.IsZero() {
+				if actual := resources.Requests[kapi.ResourceEphemeralStorage]; test.expectedStorageReq.Cmp(actual) != 0 {
+					t.Errorf("%s: ephemeral-storage request does not match; %v should be %v", test.name, actual, test.expectedStorageReq)
+				}
+			}
+			if !test.expectedGPURequest.IsZero() {
+				if actual := resources.Requests[corev1.ResourceName("nvidia.com/gpu")]; test.expectedGPURequest.Cmp(actual) != 0 {
+					t.Errorf("%s: gpu request does not match; %v should be %v", test.name, actual, test.expectedGPURequest)
+				}
+			}
+		})
+	}
+}
+
+func TestAdmissionEmitsEventOnOverride(t *testing.T) {
+	config := &clusterresourceoverride.ClusterResourceOverrideConfig{
+		MemoryRequestToLimitPercent: 50,
+	}
+	c, err := newClusterResourceOverride(config)
+	if err != nil {
+		t.Fatalf("config de/serialize failed: %v", err)
+	}
+	ns := fakeNamespace(true)
+	c.(*clusterResourceOverridePlugin).limitRangesLister = fakeLimitRangeLister{
+		namespaceLister: fakeLimitRangeNamespaceLister{},
+	}
+	c.(*clusterResourceOverridePlugin).SetProjectCache(fakeProjectCache(ns))
+	recorder := record.NewFakeRecorder(1)
+	c.(*clusterResourceOverridePlugin).SetEventRecorder(recorder)
+
+	pod := testStoragePod("1Gi", "", "")
+	attrs := admission.NewAttributesRecord(pod, nil, schema.GroupVersionKind{}, ns.Name, "name", kapi.Resource("pods").WithVersion("version"), "", admission.Create, fakeUser())
+	if err := c.(admission.MutationInterface).Admit(attrs); err != nil {
+		t.Fatalf("admission controller returned error: %v", err)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if event == "" {
+			t.Errorf("expected a non-empty event describing the override")
+		}
+	default:
+		t.Errorf("expected an event to be recorded when the plugin overrides a container's resources")
+	}
+}
+
+func TestAdmissionSkipsEventWhenUnchanged(t *testing.T) {
+	config := &clusterresourceoverride.ClusterResourceOverrideConfig{
+		MemoryRequestToLimitPercent: 50,
+	}
+	c, err := newClusterResourceOverride(config)
+	if err != nil {
+		t.Fatalf("config de/serialize failed: %v", err)
+	}
+	ns := fakeNamespace(true)
+	c.(*clusterResourceOverridePlugin).limitRangesLister = fakeLimitRangeLister{
+		namespaceLister: fakeLimitRangeNamespaceLister{},
+	}
+	c.(*clusterResourceOverridePlugin).SetProjectCache(fakeProjectCache(ns))
+	recorder := record.NewFakeRecorder(1)
+	c.(*clusterResourceOverridePlugin).SetEventRecorder(recorder)
+
+	// No memory limit means overrideContainer is a no-op, so no event should fire.
+	pod := &kapi.Pod{Spec: kapi.PodSpec{Containers: []kapi.Container{{}}}}
+	attrs := admission.NewAttributesRecord(pod, nil, schema.GroupVersionKind{}, ns.Name, "name", kapi.Resource("pods").WithVersion("version"), "", admission.Create, fakeUser())
+	if err := c.(admission.MutationInterface).Admit(attrs); err != nil {
+		t.Fatalf("admission controller returned error: %v", err)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("expected no event for an unchanged pod, got %q", event)
+	default:
+	}
+}
@@ -0,0 +1,44 @@
+package clusterresourceoverride
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	admissionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cluster_resource_override_admissions_total",
+		Help: "Count of pods the ClusterResourceOverride plugin admitted, by namespace and whether it rewrote anything.",
+	}, []string{"namespace", "decision"})
+
+	cpuRequestAdjustmentRatio = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "cluster_resource_override_cpu_request_adjustment_ratio",
+		Help:    "Ratio of adjusted to original cpu request for containers whose cpu request the plugin rewrote.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	memoryRequestAdjustmentRatio = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "cluster_resource_override_memory_request_adjustment_ratio",
+		Help:    "Ratio of adjusted to original memory request for containers whose memory request the plugin rewrote.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	limitRangeFloorAppliedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cluster_resource_override_limitrange_floor_applied_total",
+		Help: "Count of times a namespace LimitRange minimum raised a value the plugin computed, by resource.",
+	}, []string{"resource"})
+)
+
+func init() {
+	prometheus.MustRegister(admissionsTotal, cpuRequestAdjustmentRatio, memoryRequestAdjustmentRatio, limitRangeFloorAppliedTotal)
+}
+
+// recordAdjustmentRatio reports how much a request moved relative to what the user
+// submitted; ratios of exactly 1 (no-op floors raising an already-equal value) are not
+// worth a histogram sample, so callers should only call this when original is non-zero
+// and the value actually changed.
+func recordAdjustmentRatio(histogram prometheus.Histogram, originalMilli, adjustedMilli int64) {
+	if originalMilli <= 0 {
+		return
+	}
+	histogram.Observe(float64(adjustedMilli) / float64(originalMilli))
+}